@@ -134,6 +134,10 @@ type Controller struct {
 
 	jiraVerifier     *jira.Verifier
 	jiraErrorMetrics *prometheus.CounterVec
+	// jiraDryRun is set when the jira verifier only logs its changes; tags it processes are then recorded in
+	// jiraDryRunTags instead of being annotated as verified
+	jiraDryRun     bool
+	jiraDryRunTags dryRunTags
 
 	softDeleteReleaseTags bool
 	authenticationMessage string
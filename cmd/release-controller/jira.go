@@ -2,15 +2,19 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	v1 "github.com/openshift/api/image/v1"
+	"github.com/openshift/release-controller/pkg/jira"
 	releasecontroller "github.com/openshift/release-controller/pkg/release-controller"
 	"github.com/prometheus/client_golang/prometheus"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog"
+	"sync"
 	"time"
 )
 
@@ -38,7 +42,46 @@ func initializeJiraMetrics(*prometheus.CounterVec) {
 	jiraErrorMetrics.WithLabelValues(jiraImagestreamGetErr).Add(0)
 }
 
-func getNonVerifiedTagsJira(acceptedTags []*v1.TagReference) (current, previous *v1.TagReference) {
+// initializeJiraOutcomeMetrics initializes all outcomes and reasons counted by the jira verifier to 0, for the same
+// reason as initializeJiraMetrics
+func initializeJiraOutcomeMetrics(outcomeMetrics *prometheus.CounterVec) {
+	for _, outcome := range jira.Outcomes {
+		for _, reason := range jira.Reasons[outcome] {
+			outcomeMetrics.WithLabelValues(outcome, reason).Add(0)
+		}
+	}
+}
+
+// dryRunTags records, per release stream, the tags that the jira verifier processed in dry-run mode. They are only
+// kept in memory, so that the tags are verified for real once dry-run mode is disabled.
+type dryRunTags struct {
+	lock sync.Mutex
+	tags map[string]sets.String
+}
+
+// insert records that tag was processed for stream
+func (d *dryRunTags) insert(stream, tag string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.tags == nil {
+		d.tags = make(map[string]sets.String)
+	}
+	if _, ok := d.tags[stream]; !ok {
+		d.tags[stream] = sets.NewString()
+	}
+	d.tags[stream].Insert(tag)
+}
+
+// get returns a copy of the tags processed for stream
+func (d *dryRunTags) get(stream string) sets.String {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return sets.NewString(d.tags[stream].UnsortedList()...)
+}
+
+// getNonVerifiedTagsJira returns the oldest accepted tag that is neither annotated as verified nor in dryRunVerified,
+// along with the tag accepted before it
+func getNonVerifiedTagsJira(acceptedTags []*v1.TagReference, dryRunVerified sets.String) (current, previous *v1.TagReference) {
 	// get oldest non-verified tag to make sure none are missed
 	// accepted tags are returned sorted with the latest release first; reverse, so we can get oldest non-verified release
 	for i := 0; i < len(acceptedTags)/2; i++ {
@@ -46,7 +89,7 @@ func getNonVerifiedTagsJira(acceptedTags []*v1.TagReference) (current, previous
 		acceptedTags[i], acceptedTags[j] = acceptedTags[j], acceptedTags[i]
 	}
 	for index, tag := range acceptedTags {
-		if anno, ok := tag.Annotations[releasecontroller.ReleaseAnnotationIssuesVerified]; !ok || anno != "true" {
+		if anno, ok := tag.Annotations[releasecontroller.ReleaseAnnotationIssuesVerified]; (!ok || anno != "true") && !dryRunVerified.Has(tag.Name) {
 			if index == 0 {
 				return tag, nil
 			}
@@ -96,7 +139,11 @@ func (c *Controller) syncJira(key queueKey) error {
 
 	// get accepted tags
 	acceptedTags := releasecontroller.SortedRawReleaseTags(release, releasecontroller.ReleasePhaseAccepted)
-	tag, prevTag := getNonVerifiedTagsJira(acceptedTags)
+	var dryRunVerified sets.String
+	if c.jiraDryRun {
+		dryRunVerified = c.jiraDryRunTags.get(jiraDryRunStream(release))
+	}
+	tag, prevTag := getNonVerifiedTagsJira(acceptedTags, dryRunVerified)
 	if tag == nil {
 		klog.V(6).Infof("jira: All accepted tags for %s have already been verified", release.Config.Name)
 		return nil
@@ -144,13 +191,23 @@ func (c *Controller) syncJira(key queueKey) error {
 		c.jiraErrorMetrics.WithLabelValues(jiraUnableToGenerateBuglist).Inc()
 		return fmt.Errorf("jira: unable to generate bug list from %s to %s: %w", prevTag.Name, tag.Name, err)
 	}
-	var errs []error
-	if errs := append(errs, c.jiraVerifier.VerifyIssues(issueList, tag.Name)...); len(errs) != 0 {
+	results, errs := c.jiraVerifier.VerifyIssues(issueList, tag.Name)
+	if data, err := json.Marshal(results); err == nil {
+		klog.V(4).Infof("jira: verification results for %s: %s", tag.Name, data)
+	}
+	if len(errs) != 0 {
 		klog.V(4).Infof("Error(s) in jira verifier: %v", utilerrors.NewAggregate(errs))
 		c.jiraErrorMetrics.WithLabelValues(jiraVerifier).Inc()
 		return utilerrors.NewAggregate(errs)
 	}
 
+	if c.jiraDryRun {
+		// leave the imagestream untouched, so that the tag is verified for real once dry-run mode is disabled
+		klog.V(4).Infof("jira: dry run, recording %s as verified in memory only", tag.Name)
+		c.jiraDryRunTags.insert(jiraDryRunStream(release), tag.Name)
+		return nil
+	}
+
 	var lastErr error
 	err = wait.PollImmediate(15*time.Second, 1*time.Minute, func() (bool, error) {
 		// Get the latest version of ImageStream before trying to update annotations
@@ -187,3 +244,8 @@ func (c *Controller) syncJira(key queueKey) error {
 
 	return nil
 }
+
+// jiraDryRunStream returns the key of the release target stream in dryRunTags
+func jiraDryRunStream(release *releasecontroller.Release) string {
+	return fmt.Sprintf("%s/%s", release.Target.Namespace, release.Target.Name)
+}
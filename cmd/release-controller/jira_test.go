@@ -3,7 +3,11 @@ package main
 import (
 	"github.com/google/go-cmp/cmp"
 	v1 "github.com/openshift/api/image/v1"
+	"github.com/openshift/release-controller/pkg/jira"
 	releasecontroller "github.com/openshift/release-controller/pkg/release-controller"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"testing"
 )
 
@@ -11,6 +15,7 @@ func TestJiraGetNonVerifiedTags(t *testing.T) {
 	var testCases = []struct {
 		name             string
 		acceptedTags     []*v1.TagReference
+		dryRunVerified   sets.String
 		expectedCurrent  *v1.TagReference
 		expectedPrevious *v1.TagReference
 	}{{
@@ -104,9 +109,24 @@ func TestJiraGetNonVerifiedTags(t *testing.T) {
 				releasecontroller.ReleaseAnnotationIssuesVerified: "true",
 			},
 		},
+	}, {
+		name: "Multiple tags with dry-run verified tags",
+		acceptedTags: []*v1.TagReference{{
+			Name: "test3",
+		}, {
+			Name: "test2",
+		}, {
+			Name: "test1",
+			Annotations: map[string]string{
+				releasecontroller.ReleaseAnnotationIssuesVerified: "true",
+			},
+		}},
+		dryRunVerified:   sets.NewString("test2"),
+		expectedCurrent:  &v1.TagReference{Name: "test3"},
+		expectedPrevious: &v1.TagReference{Name: "test2"},
 	}}
 	for _, testCase := range testCases {
-		current, previous := getNonVerifiedTagsJira(testCase.acceptedTags)
+		current, previous := getNonVerifiedTagsJira(testCase.acceptedTags, testCase.dryRunVerified)
 		if diff := cmp.Diff(testCase.expectedCurrent, current); diff != "" {
 			t.Errorf("unexpected difference between actual and expected: %s", diff)
 		}
@@ -115,3 +135,36 @@ func TestJiraGetNonVerifiedTags(t *testing.T) {
 		}
 	}
 }
+
+func TestJiraDryRunTags(t *testing.T) {
+	var tags dryRunTags
+	if actual := tags.get("ns/stream"); actual.Len() != 0 {
+		t.Errorf("expected no tags, got %v", actual.List())
+	}
+	tags.insert("ns/stream", "test1")
+	tags.insert("ns/stream", "test2")
+	tags.insert("ns/other", "test1")
+	if actual := tags.get("ns/stream").List(); !cmp.Equal(actual, []string{"test1", "test2"}) {
+		t.Errorf("unexpected tags: %v", actual)
+	}
+	// the returned set is a copy
+	tags.get("ns/other").Insert("test2")
+	if actual := tags.get("ns/other").List(); !cmp.Equal(actual, []string{"test1"}) {
+		t.Errorf("unexpected tags: %v", actual)
+	}
+}
+
+func TestInitializeJiraOutcomeMetrics(t *testing.T) {
+	outcomeMetrics := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "outcomes"}, []string{"outcome", "reason"})
+	initializeJiraOutcomeMetrics(outcomeMetrics)
+	expected := 0
+	for _, outcome := range jira.Outcomes {
+		if len(jira.Reasons[outcome]) == 0 {
+			t.Errorf("outcome %q has no reasons", outcome)
+		}
+		expected += len(jira.Reasons[outcome])
+	}
+	if actual := testutil.CollectAndCount(outcomeMetrics); actual != expected {
+		t.Errorf("expected %d initialized series, got %d", expected, actual)
+	}
+}
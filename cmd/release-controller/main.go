@@ -86,8 +86,13 @@ type options struct {
 	githubThrottle int
 	github         flagutil.GitHubOptions
 
-	VerifyJira bool
-	jira       flagutil.JiraOptions
+	VerifyJira            bool
+	VerifyJiraDryRun      bool
+	VerifyJiraConfirm     bool
+	VerifyJiraMaxFailures int
+	VerifyJiraDenylist    []string
+	VerifyJiraRepos       []string
+	jira                  flagutil.JiraOptions
 
 	validateConfigs string
 
@@ -110,7 +115,7 @@ type options struct {
 	ProcessLegacyResults bool
 }
 
-// Add metrics for jira verifier errors
+// Add metrics for jira verifier errors and outcomes
 var (
 	jiraErrorMetrics = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -119,6 +124,13 @@ var (
 		},
 		[]string{"type"},
 	)
+	jiraOutcomeMetrics = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "release_controller_jira_verifications_total",
+			Help: "The total number of issue verifications made by the release-controller's jira verifier, by outcome and reason. A release is verified again until none of its issues fail, so an issue is counted once per attempt.",
+		},
+		[]string{"outcome", "reason"},
+	)
 )
 
 func main() {
@@ -139,6 +151,9 @@ func main() {
 		Registry: "registry.ci.openshift.org",
 
 		PrintPrunedGraph: releasecontroller.PruneGraphPrintSecret,
+
+		VerifyJiraConfirm:     true,
+		VerifyJiraMaxFailures: 10,
 	}
 	cmd := &cobra.Command{
 		Run: func(cmd *cobra.Command, arguments []string) {
@@ -183,6 +198,11 @@ func main() {
 	flagset.StringVar(&opt.ListenAddr, "listen", opt.ListenAddr, "The address to serve metrics on")
 
 	flagset.BoolVar(&opt.VerifyJira, "verify-jira", opt.VerifyJira, "Update status of issues fixed in accepted release to VERIFIED if PR was approved by QE.")
+	flagset.BoolVar(&opt.VerifyJiraDryRun, "verify-jira-dry-run", opt.VerifyJiraDryRun, "Only log the comments and status changes the jira verifier would make. Processed releases are tracked in memory instead of being marked as verified.")
+	flagset.BoolVar(&opt.VerifyJiraConfirm, "verify-jira-confirm-transitions", opt.VerifyJiraConfirm, "Re-fetch issues moved to VERIFIED by the jira verifier, retrying briefly, and report an error if the new status is not visible.")
+	flagset.IntVar(&opt.VerifyJiraMaxFailures, "verify-jira-max-consecutive-failures", opt.VerifyJiraMaxFailures, "Stop verifying the remaining issues of a release after this many consecutive failed Jira lookups. Disabled if 0.")
+	flagset.StringSliceVar(&opt.VerifyJiraDenylist, "verify-jira-denylist", opt.VerifyJiraDenylist, "The keys of jira issues that the jira verifier must never move to VERIFIED or comment on.")
+	flagset.StringSliceVar(&opt.VerifyJiraRepos, "verify-jira-enabled-repos", opt.VerifyJiraRepos, "The org/repo of the only repositories whose PRs allow the jira verifier to move issues to VERIFIED. Approved issues with PRs in other repositories are only reported. All repositories are enabled if unset.")
 	flagset.IntVar(&opt.githubThrottle, "github-throttle", 0, "Maximum number of GitHub requests per hour. Used by jira verifier.")
 
 	flagset.StringVar(&opt.validateConfigs, "validate-configs", "", "Validate configs at specified directory and exit without running operator")
@@ -402,9 +422,18 @@ func (o *options) Run() error {
 		if err != nil {
 			return fmt.Errorf("Failed to create plugin agent: %v", err)
 		}
-		c.jiraVerifier = jira.NewVerifier(jiraClient, ghClient, pluginAgent.Config())
+		c.jiraVerifier = jira.NewVerifier(jiraClient, ghClient, pluginAgent.Config(), jira.VerifierOptions{
+			DryRun:                 o.VerifyJiraDryRun,
+			ConfirmTransitions:     o.VerifyJiraConfirm,
+			OutcomeMetrics:         jiraOutcomeMetrics,
+			Denylist:               o.VerifyJiraDenylist,
+			EnabledRepos:           o.VerifyJiraRepos,
+			MaxConsecutiveFailures: o.VerifyJiraMaxFailures,
+		})
 		initializeJiraMetrics(jiraErrorMetrics)
+		initializeJiraOutcomeMetrics(jiraOutcomeMetrics)
 		c.jiraErrorMetrics = jiraErrorMetrics
+		c.jiraDryRun = o.VerifyJiraDryRun
 	}
 
 	if len(o.AuditStorage) > 0 {
@@ -1,13 +1,20 @@
 package jira
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	jiraBaseClient "github.com/andygrunwald/go-jira"
 	"github.com/openshift-eng/jira-lifecycle-plugin/pkg/helpers"
 	releasecontroller "github.com/openshift/release-controller/pkg/release-controller"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog"
 	"k8s.io/test-infra/prow/github"
 	"k8s.io/test-infra/prow/jira"
@@ -19,22 +26,206 @@ type githubClient interface {
 	CreateComment(org, repo string, number int, comment string) error
 	ListIssueComments(org, repo string, number int) ([]github.IssueComment, error)
 }
+
+type jiraIssueClient interface {
+	GetIssue(id string) (*jiraBaseClient.Issue, error)
+	GetRemoteLinks(id string) ([]jiraBaseClient.RemoteLink, error)
+	AddComment(issueID string, comment *jiraBaseClient.Comment) (*jiraBaseClient.Comment, error)
+	UpdateStatus(issueID, statusName string) error
+}
+
 type Verifier struct {
 	// jiraClient is used to retrieve external issue links and mark QA reviewed issues as VERIFIED
-	jiraClient jira.Client
+	jiraClient jiraIssueClient
 	// ghClient is used to retrieve comments on a bug's PR
 	ghClient githubClient
 	// pluginConfig is used to check whether a repository allows approving reviews as LGTM
 	pluginConfig *plugins.Configuration
+	// issueLocks serializes the verification of an issue across concurrent VerifyIssues calls
+	issueLocks keyedMutex
+	// dryRun logs the comments and transitions that would be made instead of making them; the lookups that decide
+	// whether a comment would be made are still done
+	dryRun bool
+	// confirmTransitions re-fetches an issue after moving it to VERIFIED, polling every confirmInterval until
+	// confirmTimeout for the new status to be visible
+	confirmTransitions bool
+	confirmInterval    time.Duration
+	confirmTimeout     time.Duration
+	// outcomeMetrics, if set, counts the verified issues by outcome and reason
+	outcomeMetrics *prometheus.CounterVec
+	// denylist holds the keys of the issues that are never verified
+	denylist sets.String
+	// enabledRepos, if not empty, holds the org/repo of the repositories whose PRs may get an issue moved to VERIFIED
+	enabledRepos sets.String
+	// maxConsecutiveFailures, if positive, is the number of consecutive failed Jira lookups after which the
+	// remaining issues of a VerifyIssues call are not checked
+	maxConsecutiveFailures int
 }
 
-// NewVerifier returns a Verifier configured with the provided github and jira clients and the provided pluginConfig
-func NewVerifier(jiraClient jira.Client, ghClient githubClient, pluginConfig *plugins.Configuration) *Verifier {
+// Outcomes of the verification of a single issue, used as the label of the outcome metrics
+const (
+	OutcomeVerified        = "verified"
+	OutcomeApprovedDryRun  = "approved_dry_run"
+	OutcomeReportOnly      = "report_only"
+	OutcomeNotApproved     = "not_approved"
+	OutcomeAlreadyVerified = "already_verified"
+	OutcomeSkipped         = "skipped"
+	OutcomeFailed          = "failed"
+)
+
+// Outcomes lists every outcome of the verification of a single issue
+var Outcomes = []string{OutcomeVerified, OutcomeApprovedDryRun, OutcomeReportOnly, OutcomeNotApproved, OutcomeAlreadyVerified, OutcomeSkipped, OutcomeFailed}
+
+// Reasons for the outcome of the verification of a single issue, logged along with the issue and its PRs
+const (
+	ReasonVerified               = "approved, moved to VERIFIED"
+	ReasonApprovedDryRun         = "approved, would move to VERIFIED (dry run)"
+	ReasonRepoNotEnabled         = "approved, not moved to VERIFIED as a PR repository is not enabled"
+	ReasonNotApproved            = "not approved, left in ON_QA status"
+	ReasonAlreadyVerified        = "already VERIFIED"
+	ReasonIssuePermissions       = "ignored, permissions error getting issue"
+	ReasonRemoteLinksPermissions = "ignored, permissions error getting remote links"
+	ReasonNoRemoteLinks          = "skipped, issue has no remote links"
+	ReasonNoGitHubPR             = "skipped, no GitHub PR among the remote links"
+	ReasonMalformedPRLink        = "skipped, malformed GitHub PR link"
+	ReasonNoStatus               = "skipped, issue has no status"
+	ReasonOtherRelease           = "skipped, issue does not target the release"
+	ReasonNotOnQA                = "skipped, issue is not in ON_QA status"
+	ReasonDenylisted             = "skipped, issue is denylisted"
+	ReasonGetIssueFailed         = "failed to get issue"
+	ReasonRemoteLinksFailed      = "failed to get remote links"
+	ReasonTargetReleaseInvalid   = "failed to parse target release"
+	ReasonLabelLookupFailed      = "failed to check the approval of the PRs"
+	ReasonCommentFailed          = "failed to comment on the issue"
+	ReasonTransitionFailed       = "approved, failed to move to VERIFIED"
+	ReasonTransitionUnconfirmed  = "approved, move to VERIFIED not confirmed"
+	ReasonJiraUnavailable        = "not checked, Jira unavailable"
+)
+
+// Reasons lists the reasons for each outcome of the verification of a single issue
+var Reasons = map[string][]string{
+	OutcomeVerified:        {ReasonVerified},
+	OutcomeApprovedDryRun:  {ReasonApprovedDryRun},
+	OutcomeReportOnly:      {ReasonRepoNotEnabled},
+	OutcomeNotApproved:     {ReasonNotApproved},
+	OutcomeAlreadyVerified: {ReasonAlreadyVerified},
+	OutcomeSkipped: {
+		ReasonIssuePermissions,
+		ReasonRemoteLinksPermissions,
+		ReasonNoRemoteLinks,
+		ReasonNoGitHubPR,
+		ReasonMalformedPRLink,
+		ReasonNoStatus,
+		ReasonOtherRelease,
+		ReasonNotOnQA,
+		ReasonDenylisted,
+	},
+	OutcomeFailed: {
+		ReasonGetIssueFailed,
+		ReasonRemoteLinksFailed,
+		ReasonTargetReleaseInvalid,
+		ReasonLabelLookupFailed,
+		ReasonCommentFailed,
+		ReasonTransitionFailed,
+		ReasonTransitionUnconfirmed,
+		ReasonJiraUnavailable,
+	},
+}
+
+// keyedMutex provides a mutex per key; a key's mutex is released from the map once no caller holds or waits on it
+type keyedMutex struct {
+	mutex sync.Mutex
+	locks map[string]*refCountedMutex
+}
+
+type refCountedMutex struct {
+	sync.Mutex
+	refs int
+}
+
+// lock acquires the mutex for key and returns the function that releases it
+func (k *keyedMutex) lock(key string) func() {
+	k.mutex.Lock()
+	if k.locks == nil {
+		k.locks = make(map[string]*refCountedMutex)
+	}
+	m, ok := k.locks[key]
+	if !ok {
+		m = &refCountedMutex{}
+		k.locks[key] = m
+	}
+	m.refs++
+	k.mutex.Unlock()
+
+	m.Lock()
+	return func() {
+		m.Unlock()
+		k.mutex.Lock()
+		m.refs--
+		if m.refs == 0 {
+			delete(k.locks, key)
+		}
+		k.mutex.Unlock()
+	}
+}
+
+// VerifierOptions holds the optional behavior of a Verifier
+type VerifierOptions struct {
+	// DryRun makes the Verifier only log the comments and status changes it would make
+	DryRun bool
+	// ConfirmTransitions makes the Verifier only report an issue moved to VERIFIED once Jira returns it in that status
+	ConfirmTransitions bool
+	// OutcomeMetrics, if set, is incremented with the outcome and the reason of every verified issue
+	OutcomeMetrics *prometheus.CounterVec
+	// Denylist holds the keys of issues to never verify, e.g. while they are investigated manually. They are
+	// skipped before the issue is fetched, so that neither the issue nor its PRs are commented on or transitioned,
+	// even if the PRs were approved.
+	Denylist []string
+	// EnabledRepos, if not empty, holds the org/repo of the only repositories whose PRs may get an issue moved to
+	// VERIFIED. An approved issue with a PR in any other repository is reported with OutcomeReportOnly instead.
+	EnabledRepos []string
+	// MaxConsecutiveFailures, if positive, makes VerifyIssues give up on the remaining issues once that many
+	// consecutive lookups of issues or remote links failed, reporting a single error for them
+	MaxConsecutiveFailures int
+}
+
+// NewVerifier returns a Verifier configured with the provided github and jira clients, the provided pluginConfig
+// and the provided options
+func NewVerifier(jiraClient jiraIssueClient, ghClient githubClient, pluginConfig *plugins.Configuration, options VerifierOptions) *Verifier {
 	return &Verifier{
-		jiraClient:   jiraClient,
-		ghClient:     ghClient,
-		pluginConfig: pluginConfig,
+		jiraClient:             jiraClient,
+		ghClient:               ghClient,
+		pluginConfig:           pluginConfig,
+		dryRun:                 options.DryRun,
+		confirmTransitions:     options.ConfirmTransitions,
+		confirmInterval:        time.Second,
+		confirmTimeout:         5 * time.Second,
+		outcomeMetrics:         options.OutcomeMetrics,
+		denylist:               sets.NewString(options.Denylist...),
+		enabledRepos:           sets.NewString(options.EnabledRepos...),
+		maxConsecutiveFailures: options.MaxConsecutiveFailures,
+	}
+}
+
+// jiraFailures counts the consecutive failed Jira lookups of a VerifyIssues call
+type jiraFailures struct {
+	max         int
+	consecutive int
+}
+
+// record counts a failed lookup, or resets the count after a successful one. Permission errors are specific to
+// an issue and do not say anything about the availability of Jira.
+func (f *jiraFailures) record(err error) {
+	if err == nil || jira.JiraErrorStatusCode(err) == 403 {
+		f.consecutive = 0
+		return
 	}
+	f.consecutive++
+}
+
+// unavailable returns whether there were enough consecutive failures to consider Jira unavailable
+func (f *jiraFailures) unavailable() bool {
+	return f.max > 0 && f.consecutive >= f.max
 }
 
 type pr struct {
@@ -43,6 +234,11 @@ type pr struct {
 	prNum int
 }
 
+// url returns the github.com URL of the pull request
+func (p pr) url() string {
+	return fmt.Sprintf("https://github.com/%s/%s/pull/%d", p.org, p.repo, p.prNum)
+}
+
 func issueTargetReleaseCheck(issue *jiraBaseClient.Issue, tagRelease string, tagName string) (bool, error) {
 	targetVersion, err := helpers.GetIssueTargetVersion(issue)
 	if err != nil {
@@ -87,6 +283,36 @@ func (c *Verifier) ghUnlabeledPRs(extPR pr) ([]pr, error) {
 	return unlabeledPRs, nil
 }
 
+// prCache remembers the github lookups made for each PR during a single VerifyIssues call, as one PR
+// often fixes several of the issues in a release
+type prCache struct {
+	labels    map[pr]prLabelResult
+	commented map[pr]bool
+}
+
+type prLabelResult struct {
+	unlabeled []pr
+	err       error
+}
+
+func newPRCache() *prCache {
+	return &prCache{
+		labels:    make(map[pr]prLabelResult),
+		commented: make(map[pr]bool),
+	}
+}
+
+// unlabeledPRs returns the cached result of ghUnlabeledPRs for the PR, calling it on the first lookup.
+// Errors are cached too, so that every issue linked to a PR whose labels could not be fetched reports it.
+func (p *prCache) unlabeledPRs(extPR pr, lookup func(pr) ([]pr, error)) ([]pr, error) {
+	if result, ok := p.labels[extPR]; ok {
+		return result.unlabeled, result.err
+	}
+	unlabeled, err := lookup(extPR)
+	p.labels[extPR] = prLabelResult{unlabeled: unlabeled, err: err}
+	return unlabeled, err
+}
+
 func (c *Verifier) commentOnPR(extPR pr, message string) (error, bool) {
 	// Get the comments from that PR
 	comments, err := c.ghClient.ListIssueComments(extPR.org, extPR.repo, extPR.prNum)
@@ -99,6 +325,10 @@ func (c *Verifier) commentOnPR(extPR pr, message string) (error, bool) {
 			return nil, false
 		}
 	}
+	if c.dryRun {
+		klog.Infof("Dry run: would comment on PR %s: %q", extPR.url(), message)
+		return nil, false
+	}
 	// If the message hasn't already been posted, post it.
 	err = c.ghClient.CreateComment(extPR.org, extPR.repo, extPR.prNum, message)
 	if err != nil {
@@ -107,34 +337,41 @@ func (c *Verifier) commentOnPR(extPR pr, message string) (error, bool) {
 	return err, true
 }
 
-func (c *Verifier) verifyExtPRs(issue *jiraBaseClient.Issue, extPRs []pr, errs *[]error, tagName string) (ticketMessage string, isSuccess bool) {
+func (c *Verifier) verifyExtPRs(issue *jiraBaseClient.Issue, extPRs []pr, cache *prCache, errs *[]error, tagName string) (ticketMessage string, isSuccess bool) {
 	var success bool
 	message := fmt.Sprintf("Fix included in accepted release %s", tagName)
 	var unlabeledPRs []pr
+	// errs may already hold the errors of other issues; only the ones met for this issue are relevant
+	errCount := len(*errs)
 	if !strings.EqualFold(issue.Fields.Status.Name, jira.StatusOnQA) {
 		klog.V(4).Infof("Issue %s is in %s status; ignoring", issue.Key, issue.Fields.Status.Name)
 		return message, false
 	} else {
 		for _, extPR := range extPRs {
-			var newErr error
-			unlabeledPRs, newErr = c.ghUnlabeledPRs(extPR)
+			unlabeled, newErr := cache.unlabeledPRs(extPR, c.ghUnlabeledPRs)
 			if newErr != nil {
-				*errs = append(*errs, newErr)
+				// the error is cached for every issue linked to the PR; say which issue it is reported for
+				*errs = append(*errs, fmt.Errorf("issue %s: %w", issue.Key, newErr))
 				return "", false
 			}
+			unlabeledPRs = append(unlabeledPRs, unlabeled...)
+			if cache.commented[extPR] {
+				continue
+			}
 			// Comment on the PR saying that this PR is included in the release
-			prError, prSuccess := c.commentOnPR(extPR, message)
-			if !prSuccess {
-				klog.Warningf("Failed to comment to PR [%s/%s#%d]: %v", extPR.org, extPR.repo, extPR.prNum, prError)
+			if prError, _ := c.commentOnPR(extPR, message); prError != nil {
+				klog.Warningf("Failed to comment to PR %s: %v", extPR.url(), prError)
+			} else {
+				cache.commented[extPR] = true
 			}
 		}
 	}
-	if len(unlabeledPRs) > 0 || len(*errs) > 0 {
+	if len(unlabeledPRs) > 0 || len(*errs) > errCount {
 		message = fmt.Sprintf("%s\nJira issue will not be automatically moved to %s for the following reasons:", message, jira.StatusVerified)
 		for _, extPR := range unlabeledPRs {
-			message = fmt.Sprintf("%s\n- PR %s/%s#%d not approved by the QA Contact", message, extPR.org, extPR.repo, extPR.prNum)
+			message = fmt.Sprintf("%s\n- PR %s/%s#%d (%s) not approved by the QA Contact", message, extPR.org, extPR.repo, extPR.prNum, extPR.url())
 		}
-		for _, err := range *errs {
+		for _, err := range (*errs)[errCount:] {
 			message = fmt.Sprintf("%s\n- %s", message, err)
 		}
 		message = fmt.Sprintf("%s\n\nThis issue must now be manually moved to VERIFIED", message)
@@ -147,13 +384,13 @@ func (c *Verifier) verifyExtPRs(issue *jiraBaseClient.Issue, extPRs []pr, errs *
 	}
 	if success {
 		message = fmt.Sprintf("%s\nAll linked GitHub PRs have been approved by a QA contact; updating bug status to VERIFIED", message)
+		for _, extPR := range extPRs {
+			message = fmt.Sprintf("%s\n- PR %s/%s#%d (%s)", message, extPR.org, extPR.repo, extPR.prNum, extPR.url())
+		}
 	}
 	return message, success
 }
 
-// VerifyIssues takes a list of jira issues IDs and for each issue changes the status to VERIFIED if the issue was
-// reviewed and lgtm'd by the bug's QA Contact
-
 func (c *Verifier) commentIssue(errs *[]error, issue *jiraBaseClient.Issue, message string) {
 	if message == "" {
 		return
@@ -171,6 +408,10 @@ func (c *Verifier) commentIssue(errs *[]error, issue *jiraBaseClient.Issue, mess
 		}
 	}
 
+	if c.dryRun {
+		klog.Infof("Dry run: would comment on issue %s: %q", issue.Key, message)
+		return
+	}
 	restrictedComment := &jiraBaseClient.CommentVisibility{
 		Type:  "group",
 		Value: "Red Hat Employee",
@@ -182,53 +423,218 @@ func (c *Verifier) commentIssue(errs *[]error, issue *jiraBaseClient.Issue, mess
 	return
 }
 
-func (c *Verifier) VerifyIssues(issues []string, tagName string) []error {
+// Result is the result of the verification of a single issue
+type Result struct {
+	// Issue is the key of the issue
+	Issue string `json:"issue"`
+	// PRs are the URLs of the GitHub PRs linked to the issue
+	PRs []string `json:"prs,omitempty"`
+	// Status is the status of the issue before it was verified, if it could be retrieved
+	Status string `json:"status,omitempty"`
+	// Outcome is one of the Outcome* constants
+	Outcome string `json:"outcome"`
+	// Reason is one of the Reason* constants
+	Reason string `json:"reason"`
+}
+
+// decided returns a copy of the result with the given outcome and reason
+func (r Result) decided(outcome, reason string) Result {
+	r.Outcome, r.Reason = outcome, reason
+	return r
+}
+
+// VerifyIssues takes a list of jira issues IDs and for each issue changes the status to VERIFIED if the issue was
+// reviewed and lgtm'd by the bug's QA Contact. It returns the result of every issue, in the order they were given.
+func (c *Verifier) VerifyIssues(issues []string, tagName string) ([]Result, []error) {
 	tagSemVer, err := releasecontroller.SemverParseTolerant(tagName)
 	if err != nil {
-		return []error{fmt.Errorf("failed to parse tag `%s` semver: %w", tagName, err)}
+		return nil, []error{fmt.Errorf("failed to parse tag `%s` semver: %w", tagName, err)}
 	}
 	tagRelease := releasecontroller.SemverToMajorMinor(tagSemVer)
-	jiraPRs, errs := getPRs(issues, c.jiraClient)
-	for issueID, extPRs := range jiraPRs {
-		issue, err := c.jiraClient.GetIssue(issueID)
-		if jira.JiraErrorStatusCode(err) == 403 {
-			klog.Warningf("Permissions error getting issue %s; ignoring", issueID)
-			continue
+	var issueIDs []string
+	seen := sets.NewString()
+	for _, issueID := range issues {
+		if !seen.Has(issueID) {
+			seen.Insert(issueID)
+			issueIDs = append(issueIDs, issueID)
 		}
-		if err != nil {
-			errs = append(errs, fmt.Errorf("unable to get jira ID %s: %w", issueID, err))
-			continue
+	}
+	failures := &jiraFailures{max: c.maxConsecutiveFailures}
+	jiraPRs, skipped, errs := getPRs(issueIDs, c.jiraClient, failures)
+	cache := newPRCache()
+	var results []Result
+	var unchecked int
+	for _, issueID := range issueIDs {
+		var result Result
+		issue, isSkipped := skipped[issueID]
+		extPRs, hasPRs := jiraPRs[issueID]
+		switch {
+		case isSkipped:
+			result = Result{Issue: issueID, Outcome: issue.outcome, Reason: issue.reason}
+		case !hasPRs || failures.unavailable():
+			// getPRs stopped before reaching the issue, or too many lookups failed since
+			result = Result{Issue: issueID, PRs: prURLs(extPRs), Outcome: OutcomeFailed, Reason: ReasonJiraUnavailable}
+			unchecked++
+		default:
+			result = c.verifyIssue(issueID, extPRs, cache, tagRelease, tagName, failures, &errs)
 		}
-		checkTargetRelease, tagError := issueTargetReleaseCheck(issue, tagRelease, tagName)
-		if checkTargetRelease {
-			if tagError == nil {
-				// the issue does not have a release tag
-				continue
-			}
-			// the release tag format is not as expected
-			errs = append(errs, tagError)
-			continue
+		c.recordDecision(tagName, result)
+		results = append(results, result)
+	}
+	if unchecked > 0 {
+		errs = append(errs, fmt.Errorf("Jira unavailable after %d consecutive failures, aborting the verification of the remaining %d issues", failures.consecutive, unchecked))
+	}
+	return results, errs
+}
+
+// recordDecision logs the decision made for an issue and counts its outcome and reason
+func (c *Verifier) recordDecision(tagName string, result Result) {
+	if c.outcomeMetrics != nil {
+		c.outcomeMetrics.WithLabelValues(result.Outcome, result.Reason).Inc()
+	}
+	prs := "none"
+	if len(result.PRs) > 0 {
+		prs = strings.Join(result.PRs, ", ")
+	}
+	klog.Infof("Jira verification of issue %s for %s (PRs: %s): %s", result.Issue, tagName, prs, result.Reason)
+}
+
+// verifyIssue comments on and, if all of its PRs were approved, moves a single issue to VERIFIED. It returns the
+// result of the verification. Concurrent calls for the same issue are serialized, so that the second caller
+// observes the first caller's transition and comment instead of duplicating them (e.g. when release streams share
+// a fix).
+func (c *Verifier) verifyIssue(issueID string, extPRs []pr, cache *prCache, tagRelease, tagName string, failures *jiraFailures, errs *[]error) (result Result) {
+	defer c.issueLocks.lock(issueID)()
+	result = Result{Issue: issueID, PRs: prURLs(extPRs)}
+	if c.denylist.Has(issueID) {
+		return result.decided(OutcomeSkipped, ReasonDenylisted)
+	}
+	// errs is shared by all the issues of a VerifyIssues call; only the errors met for this issue make it fail
+	errCount := len(*errs)
+	defer func() {
+		// the only errors not already reported as a failure are those of commenting on the issue
+		if len(*errs) > errCount && result.Outcome != OutcomeFailed {
+			result = result.decided(OutcomeFailed, ReasonCommentFailed)
+		}
+	}()
+	issue, err := c.jiraClient.GetIssue(issueID)
+	failures.record(err)
+	if jira.JiraErrorStatusCode(err) == 403 {
+		klog.Warningf("Permissions error getting issue %s; ignoring", issueID)
+		return result.decided(OutcomeSkipped, ReasonIssuePermissions)
+	}
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("unable to get jira ID %s: %w", issueID, err))
+		return result.decided(OutcomeFailed, ReasonGetIssueFailed)
+	}
+	if issue.Fields == nil || issue.Fields.Status == nil || issue.Fields.Status.Name == "" {
+		// the status checks below cannot be trusted without a status; never transition such an issue
+		klog.Warningf("Jira issue %s was returned without a status; ignoring", issueID)
+		return result.decided(OutcomeSkipped, ReasonNoStatus)
+	}
+	result.Status = issue.Fields.Status.Name
+	checkTargetRelease, tagError := issueTargetReleaseCheck(issue, tagRelease, tagName)
+	if checkTargetRelease {
+		if tagError == nil {
+			// the issue does not have a release tag
+			return result.decided(OutcomeSkipped, ReasonOtherRelease)
 		}
-		message, success := c.verifyExtPRs(issue, extPRs, &errs, tagName)
-		if !strings.EqualFold(issue.Fields.Status.Name, jira.StatusOnQA) {
-			if strings.EqualFold(issue.Fields.Status.Name, jira.StatusVerified) {
-				c.commentIssue(&errs, issue, message)
+		// the release tag format is not as expected
+		*errs = append(*errs, tagError)
+		return result.decided(OutcomeFailed, ReasonTargetReleaseInvalid)
+	}
+	message, success := c.verifyExtPRs(issue, extPRs, cache, errs, tagName)
+	if len(*errs) > errCount {
+		return result.decided(OutcomeFailed, ReasonLabelLookupFailed)
+	}
+	if !strings.EqualFold(issue.Fields.Status.Name, jira.StatusOnQA) {
+		if strings.EqualFold(issue.Fields.Status.Name, jira.StatusVerified) {
+			c.commentIssue(errs, issue, message)
+			return result.decided(OutcomeAlreadyVerified, ReasonAlreadyVerified)
+		}
+		klog.V(4).Infof("Jira issue %s is in %s status; not verifying", issue.Key, issue.Fields.Status.Name)
+		return result.decided(OutcomeSkipped, ReasonNotOnQA)
+	}
+
+	if success {
+		if repos := c.disabledRepos(extPRs); len(repos) > 0 {
+			// the issue comment would announce the transition; the PRs were commented on already
+			klog.Infof("Jira issue %s was approved, but verification is not enabled for %s", issue.Key, strings.Join(repos, ", "))
+			return result.decided(OutcomeReportOnly, ReasonRepoNotEnabled)
+		}
+	}
+
+	c.commentIssue(errs, issue, message)
+
+	if success {
+		if c.dryRun {
+			return result.decided(OutcomeApprovedDryRun, ReasonApprovedDryRun)
+		}
+		klog.V(4).Infof("Updating issue %s (current status %s) to VERIFIED status", issue.ID, issue.Fields.Status.Name)
+		if err := c.jiraClient.UpdateStatus(issue.ID, jira.StatusVerified); err != nil {
+			*errs = append(*errs, fmt.Errorf("failed to update status for issue %s: %w", issue.Key, err))
+			return result.decided(OutcomeFailed, ReasonTransitionFailed)
+		}
+		if c.confirmTransitions {
+			// workflow rules can reject a transition without failing the request; make sure the status actually changed
+			if err := c.confirmStatus(issue.ID, jira.StatusVerified); err != nil {
+				*errs = append(*errs, fmt.Errorf("failed to update status for issue %s: %w", issue.Key, err))
+				return result.decided(OutcomeFailed, ReasonTransitionUnconfirmed)
 			}
-			continue
 		}
+		return result.decided(OutcomeVerified, ReasonVerified)
+	}
+	klog.V(4).Infof("Jira issue %s (current status %s) not approved by QA contact", issue.Key, issue.Fields.Status.Name)
+	return result.decided(OutcomeNotApproved, ReasonNotApproved)
+}
 
-		c.commentIssue(&errs, issue, message)
+// disabledRepos returns the org/repo of the PRs' repositories that are not enabled for verification
+func (c *Verifier) disabledRepos(extPRs []pr) []string {
+	if c.enabledRepos.Len() == 0 {
+		return nil
+	}
+	disabled := sets.NewString()
+	for _, extPR := range extPRs {
+		if repo := fmt.Sprintf("%s/%s", extPR.org, extPR.repo); !c.enabledRepos.Has(repo) {
+			disabled.Insert(repo)
+		}
+	}
+	return disabled.List()
+}
 
-		if success {
-			klog.V(4).Infof("Updating issue %s (current status %s) to VERIFIED status", issue.ID, issue.Fields.Status.Name)
-			if err := c.jiraClient.UpdateStatus(issue.ID, jira.StatusVerified); err != nil {
-				errs = append(errs, fmt.Errorf("failed to update status for issue %s: %w", issue.Key, err))
+// confirmStatus re-fetches an issue until it is in the expected status, and returns the last error seen if it
+// is still not once confirmTimeout is reached
+func (c *Verifier) confirmStatus(issueID, status string) error {
+	var lastErr error
+	err := wait.PollImmediate(c.confirmInterval, c.confirmTimeout, func() (bool, error) {
+		issue, err := c.jiraClient.GetIssue(issueID)
+		if err != nil {
+			lastErr = fmt.Errorf("unable to confirm status change: %w", err)
+			return false, nil
+		}
+		if issue.Fields == nil || issue.Fields.Status == nil || !strings.EqualFold(issue.Fields.Status.Name, status) {
+			var current string
+			if issue.Fields != nil && issue.Fields.Status != nil {
+				current = issue.Fields.Status.Name
 			}
-		} else {
-			klog.V(4).Infof("Jira issue %s (current status %s) not approved by QA contact", issue.Key, issue.Fields.Status.Name)
+			lastErr = fmt.Errorf("status is %q after the update to %s was accepted", current, status)
+			return false, nil
 		}
+		return true, nil
+	})
+	if err != nil && lastErr != nil && errors.Is(err, wait.ErrWaitTimeout) {
+		return lastErr
+	}
+	return err
+}
+
+// prURLs returns the URLs of the given PRs
+func prURLs(extPRs []pr) []string {
+	var urls []string
+	for _, extPR := range extPRs {
+		urls = append(urls, extPR.url())
 	}
-	return errs
+	return urls
 }
 
 // TODO - this should be moved to the jira-lifecycle-plugin
@@ -255,31 +661,56 @@ func PullFromIdentifier(identifier string) (org, repo string, num int, err error
 	if err != nil {
 		return "", "", 0, fmt.Errorf("invalid pull identifier: could not parse %s as number: %w", parts[3], err)
 	}
+	if parts[0] == "" || parts[1] == "" || number <= 0 {
+		return "", "", 0, fmt.Errorf("invalid pull identifier: org, repo and a positive pull number are required: %q", identifier)
+	}
 
 	return parts[0], parts[1], number, nil
 }
 
 // getPRs identifies jira issues and the associated github PRs fixed in a release from
-// a given issue-list generated by `oc adm release info --bugs=git-cache-path --ouptut=name from-tag to-tag`
-func getPRs(input []string, jiraClient jira.Client) (map[string][]pr, []error) {
-	jiraPRs := make(map[string][]pr)
-	var errs []error
+// a given issue-list generated by `oc adm release info --bugs=git-cache-path --ouptut=name from-tag to-tag`.
+// Issues without any GitHub PR, or with a GitHub PR link that cannot be parsed, are returned in skipped, along with
+// the reason they were skipped. Once failures considers Jira unavailable, the remaining issues are in neither map.
+func getPRs(input []string, jiraClient jiraIssueClient, failures *jiraFailures) (jiraPRs map[string][]pr, skipped map[string]skippedIssue, errs []error) {
+	jiraPRs = make(map[string][]pr)
+	skipped = make(map[string]skippedIssue)
 	for _, jiraID := range input {
+		if failures.unavailable() {
+			break
+		}
 		extBugs, err := jiraClient.GetRemoteLinks(jiraID)
+		failures.record(err)
 		if jira.JiraErrorStatusCode(err) == 403 {
 			klog.Warningf("Permissions error getting issue %s; ignoring", jiraID)
+			skipped[jiraID] = skippedIssue{outcome: OutcomeSkipped, reason: ReasonRemoteLinksPermissions}
 			continue
 		}
 		if err != nil {
 			errs = append(errs, fmt.Errorf("failed to get external bugs for jira issue %s: %w", jiraID, err))
+			skipped[jiraID] = skippedIssue{outcome: OutcomeFailed, reason: ReasonRemoteLinksFailed}
+			continue
+		}
+		if len(extBugs) == 0 {
+			// the issue was never linked to anything; there is nothing to verify against
+			skipped[jiraID] = skippedIssue{outcome: OutcomeSkipped, reason: ReasonNoRemoteLinks}
 			continue
 		}
-		foundPR := false
+		foundPR, malformedPR := false, false
 		for _, extBug := range extBugs {
-			if strings.HasPrefix(extBug.Object.URL, "https://github.com/") {
-				org, repo, num, err := PullFromIdentifier(extBug.Object.URL)
+			if extBug.Object == nil {
+				continue
+			}
+			if identifier, ok := githubPullIdentifier(extBug.Object.URL); ok {
+				org, repo, num, err := PullFromIdentifier(identifier)
+				var notForPull *identifierNotForPull
+				if errors.As(err, &notForPull) {
+					// links to GitHub issues or commits are not fixes to verify
+					continue
+				}
 				if err != nil {
-					klog.Warningf("failed to parse PR details from the identifier")
+					klog.Warningf("Malformed GitHub PR link %q for jira issue %s: %v", extBug.Object.URL, jiraID, err)
+					malformedPR = true
 					continue
 				}
 				if existingPRs, ok := jiraPRs[jiraID]; ok {
@@ -290,10 +721,52 @@ func getPRs(input []string, jiraClient jira.Client) (map[string][]pr, []error) {
 				foundPR = true
 			}
 		}
+		if malformedPR {
+			// the approval of a PR that cannot be identified cannot be checked; never verify the issue without it
+			delete(jiraPRs, jiraID)
+			skipped[jiraID] = skippedIssue{outcome: OutcomeSkipped, reason: ReasonMalformedPRLink}
+			continue
+		}
 		if !foundPR {
 			// sometimes people ignore the bot and manually change the jira tags, resulting in an issue not being linked; ignore these
-			klog.V(5).Infof("Failed to identify associated GitHub PR for jira issue %s", jiraID)
+			klog.Infof("Jira issue %s has no GitHub PR among its %d remote links (hosts: %s)", jiraID, len(extBugs), strings.Join(remoteLinkHosts(extBugs), ", "))
+			skipped[jiraID] = skippedIssue{outcome: OutcomeSkipped, reason: ReasonNoGitHubPR}
+		}
+	}
+	return jiraPRs, skipped, errs
+}
+
+// skippedIssue is the outcome of an issue that getPRs found no GitHub PR to verify for, and the reason for it
+type skippedIssue struct {
+	outcome string
+	reason  string
+}
+
+// githubPullIdentifier returns the path of a remote link URL pointing at github.com in the form expected by
+// PullFromIdentifier. Links to other hosts are rejected, as the github client can only act on github.com.
+func githubPullIdentifier(rawURL string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return "", false
+	}
+	if host := strings.ToLower(u.Host); host != "github.com" && host != "www.github.com" {
+		return "", false
+	}
+	return strings.TrimPrefix(u.Path, "/"), true
+}
+
+// remoteLinkHosts returns the sorted, de-duplicated hosts of the given remote links so that triagers can see
+// what an issue without a GitHub PR was linked to instead
+func remoteLinkHosts(links []jiraBaseClient.RemoteLink) []string {
+	hosts := sets.NewString()
+	for _, link := range links {
+		host := "unknown"
+		if link.Object != nil {
+			if u, err := url.Parse(link.Object.URL); err == nil && u.Host != "" {
+				host = u.Host
+			}
 		}
+		hosts.Insert(host)
 	}
-	return jiraPRs, errs
+	return hosts.List()
 }
@@ -1,15 +1,25 @@
 package jira
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/andygrunwald/go-jira"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog"
 	"k8s.io/test-infra/prow/github"
 	"k8s.io/test-infra/prow/github/fakegithub"
+	prowjira "k8s.io/test-infra/prow/jira"
 	"k8s.io/test-infra/prow/jira/fakejira"
 	"k8s.io/test-infra/prow/plugins"
 )
@@ -74,11 +84,11 @@ func TestGetPRS(t *testing.T) {
 		{
 			ID:           1234,
 			Self:         "https://issues.redhat.com/rest/api/2/issue/OCPBUGSM-0000/remotelink/1234",
-			GlobalID:     "EXTBZ-14641175-Github-openshift/kube-state-metrics/pull/000",
+			GlobalID:     "EXTBZ-14641175-Github-openshift/kube-state-metrics/pull/100",
 			Application:  nil,
 			Relationship: "external trackers",
 			Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/openshift/kube-state-metrics/pull/000",
+				URL:   "https://github.com/openshift/kube-state-metrics/pull/100",
 				Title: "Red Hat Errata Tool 95802",
 			},
 		},
@@ -88,7 +98,7 @@ func TestGetPRS(t *testing.T) {
 
 	c := &fakejira.FakeClient{Issues: []*jira.Issue{&issue}, RemovedLinks: removeLinkArray, ExistingLinks: remoteLinks}
 
-	extLinks, errors := getPRs([]string{"OCPBUGS-0000"}, c)
+	extLinks, _, errors := getPRs([]string{"OCPBUGS-0000"}, c, &jiraFailures{})
 
 	if len(errors) != 0 {
 		t.Fatalf("unexpected errors: %s", errors)
@@ -101,12 +111,212 @@ func TestGetPRS(t *testing.T) {
 		if len(value) != 1 {
 			t.Fatalf("unexpected number of external links: %v", extLinks)
 		}
-		if !reflect.DeepEqual(value[0], pr{org: "openshift", repo: "kube-state-metrics", prNum: 0}) {
-			t.Fatalf("unexpected value for the external links. Expecting: %v but got: %v", pr{org: "openshift", repo: "kube-state-metrics", prNum: 0}, value[0])
+		if !reflect.DeepEqual(value[0], pr{org: "openshift", repo: "kube-state-metrics", prNum: 100}) {
+			t.Fatalf("unexpected value for the external links. Expecting: %v but got: %v", pr{org: "openshift", repo: "kube-state-metrics", prNum: 100}, value[0])
 		}
 	}
 }
 
+func TestGetPRsSkipReasons(t *testing.T) {
+	testCases := []struct {
+		name           string
+		remoteLinks    []jira.RemoteLink
+		remoteLinksErr error
+		expected       string
+		outcome        string
+		expectedErrs   int
+	}{
+		{
+			name:           "Permissions error",
+			remoteLinksErr: &prowjira.JiraError{StatusCode: 403, OriginalError: errors.New("forbidden")},
+			expected:       ReasonRemoteLinksPermissions,
+			outcome:        OutcomeSkipped,
+		},
+		{
+			name:           "Jira error",
+			remoteLinksErr: errors.New("injected error"),
+			expected:       ReasonRemoteLinksFailed,
+			outcome:        OutcomeFailed,
+			expectedErrs:   1,
+		},
+		{
+			name:     "No remote links",
+			expected: ReasonNoRemoteLinks,
+			outcome:  OutcomeSkipped,
+		},
+		{
+			name:     "Only non-GitHub remote links",
+			expected: ReasonNoGitHubPR,
+			outcome:  OutcomeSkipped,
+			remoteLinks: []jira.RemoteLink{
+				{
+					ID:     1234,
+					Object: &jira.RemoteLinkObject{URL: "https://errata.devel.redhat.com/advisory/0000"},
+				},
+				{
+					ID:     1235,
+					Object: &jira.RemoteLinkObject{URL: "https://gitlab.com/openshift/kube-state-metrics/-/merge_requests/1"},
+				},
+			},
+		},
+		{
+			name:     "Only GitHub issue links",
+			expected: ReasonNoGitHubPR,
+			outcome:  OutcomeSkipped,
+			remoteLinks: []jira.RemoteLink{
+				{
+					ID:     1234,
+					Object: &jira.RemoteLinkObject{URL: "https://github.com/openshift/kube-state-metrics/issues/1"},
+				},
+			},
+		},
+		{
+			name:     "Malformed GitHub PR link next to a valid PR",
+			expected: ReasonMalformedPRLink,
+			outcome:  OutcomeSkipped,
+			remoteLinks: []jira.RemoteLink{
+				{
+					ID:     1234,
+					Object: &jira.RemoteLinkObject{URL: "https://github.com/openshift/kube-state-metrics/pull/100"},
+				},
+				{
+					ID:     1235,
+					Object: &jira.RemoteLinkObject{URL: "https://github.com/openshift/kube-state-metrics/pull/abc"},
+				},
+			},
+		},
+		{
+			name:     "Only malformed GitHub PR links",
+			expected: ReasonMalformedPRLink,
+			outcome:  OutcomeSkipped,
+			remoteLinks: []jira.RemoteLink{
+				{
+					ID:     1234,
+					Object: &jira.RemoteLinkObject{URL: "https://github.com//kube-state-metrics/pull/1"},
+				},
+				{
+					ID:     1235,
+					Object: &jira.RemoteLinkObject{URL: "https://github.com/openshift/kube-state-metrics/pull/0"},
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &narrowJiraClient{remoteLinks: tc.remoteLinks, remoteLinksErr: tc.remoteLinksErr}
+			extLinks, skipped, errs := getPRs([]string{"OCPBUGS-0000"}, c, &jiraFailures{})
+			if len(errs) != tc.expectedErrs {
+				t.Fatalf("expected %d errors, got: %v", tc.expectedErrs, errs)
+			}
+			if len(extLinks) != 0 {
+				t.Errorf("expected no PRs, got: %v", extLinks)
+			}
+			if actual := skipped["OCPBUGS-0000"]; actual.reason != tc.expected || actual.outcome != tc.outcome {
+				t.Errorf("expected skip reason %q with outcome %q, got %q with outcome %q", tc.expected, tc.outcome, actual.reason, actual.outcome)
+			}
+		})
+	}
+}
+
+func TestGithubPullIdentifier(t *testing.T) {
+	testCases := []struct {
+		url        string
+		identifier string
+		ok         bool
+	}{
+		{url: "https://github.com/openshift/origin/pull/1", identifier: "openshift/origin/pull/1", ok: true},
+		{url: "https://www.github.com/openshift/origin/pull/1/", identifier: "openshift/origin/pull/1/", ok: true},
+		{url: "http://GitHub.com/openshift/origin/pull/1/files?diff=split#top", identifier: "openshift/origin/pull/1/files", ok: true},
+		{url: "https://github.example.com/openshift/origin/pull/1"},
+		{url: "https://errata.devel.redhat.com/advisory/0000"},
+		{url: "github.com/openshift/origin/pull/1"},
+	}
+	for _, tc := range testCases {
+		identifier, ok := githubPullIdentifier(tc.url)
+		if identifier != tc.identifier || ok != tc.ok {
+			t.Errorf("%s: expected (%q, %t), got (%q, %t)", tc.url, tc.identifier, tc.ok, identifier, ok)
+		}
+	}
+}
+
+func TestPRURL(t *testing.T) {
+	extPR := pr{org: "openshift", repo: "kube-state-metrics", prNum: 100}
+	if actual, expected := extPR.url(), "https://github.com/openshift/kube-state-metrics/pull/100"; actual != expected {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+}
+
+func TestRemoteLinkHosts(t *testing.T) {
+	links := []jira.RemoteLink{
+		{Object: &jira.RemoteLinkObject{URL: "https://errata.devel.redhat.com/advisory/0000"}},
+		{Object: &jira.RemoteLinkObject{URL: "https://gitlab.com/openshift/kube-state-metrics/-/merge_requests/1"}},
+		{Object: &jira.RemoteLinkObject{URL: "https://errata.devel.redhat.com/advisory/0001"}},
+		{Object: &jira.RemoteLinkObject{URL: ""}},
+		{},
+	}
+	expected := []string{"errata.devel.redhat.com", "gitlab.com", "unknown"}
+	if actual := remoteLinkHosts(links); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected hosts %v, got %v", expected, actual)
+	}
+}
+
+func TestPullFromIdentifier(t *testing.T) {
+	testCases := []struct {
+		name        string
+		identifier  string
+		expected    pr
+		expectedErr bool
+	}{
+		{
+			name:       "Full URL",
+			identifier: "https://github.com/openshift/kube-state-metrics/pull/100",
+			expected:   pr{org: "openshift", repo: "kube-state-metrics", prNum: 100},
+		},
+		{
+			name:       "Files URL",
+			identifier: "https://github.com/openshift/kube-state-metrics/pull/100/files",
+			expected:   pr{org: "openshift", repo: "kube-state-metrics", prNum: 100},
+		},
+		{
+			name:        "Issue URL",
+			identifier:  "https://github.com/openshift/kube-state-metrics/issues/100",
+			expectedErr: true,
+		},
+		{
+			name:        "Empty org",
+			identifier:  "https://github.com//kube-state-metrics/pull/100",
+			expectedErr: true,
+		},
+		{
+			name:        "Empty repo",
+			identifier:  "https://github.com/openshift//pull/100",
+			expectedErr: true,
+		},
+		{
+			name:        "Zero pull number",
+			identifier:  "https://github.com/openshift/kube-state-metrics/pull/0",
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			org, repo, num, err := PullFromIdentifier(tc.identifier)
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatalf("expected an error for %q, got %s/%s#%d", tc.identifier, org, repo, num)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := (pr{org: org, repo: repo, prNum: num}); !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
 func TestIssueTargetReleaseCheck(t *testing.T) {
 	issueJSON := "{\n \"id\":\"0000\",\n\"key\":\"OCPBUGS-0000\",\n\"fields\":{\n \"customfield_12319940\": [\n{\n\"name\": \"4.11.Z\"\n}\n]\n}\n}"
 
@@ -259,7 +469,7 @@ func TestVerifyIssues(t *testing.T) {
 			expected: expectedResult{
 				errors:  nil,
 				status:  "",
-				message: "Fix included in accepted release 4.10\nJira issue will not be automatically moved to VERIFIED for the following reasons:\n- PR openshift/vmware-vsphere-csi-driver-operator#105 not approved by the QA Contact\n\nThis issue must now be manually moved to VERIFIED by Jack Smith",
+				message: "Fix included in accepted release 4.10\nJira issue will not be automatically moved to VERIFIED for the following reasons:\n- PR openshift/vmware-vsphere-csi-driver-operator#105 (https://github.com/openshift/vmware-vsphere-csi-driver-operator/pull/105) not approved by the QA Contact\n\nThis issue must now be manually moved to VERIFIED by Jack Smith",
 			},
 		},
 		{
@@ -276,7 +486,7 @@ func TestVerifyIssues(t *testing.T) {
 			expected: expectedResult{
 				errors:  nil,
 				status:  "Verified",
-				message: "Fix included in accepted release 4.10\nAll linked GitHub PRs have been approved by a QA contact; updating bug status to VERIFIED",
+				message: "Fix included in accepted release 4.10\nAll linked GitHub PRs have been approved by a QA contact; updating bug status to VERIFIED\n- PR openshift/vmware-vsphere-csi-driver-operator#105 (https://github.com/openshift/vmware-vsphere-csi-driver-operator/pull/105)",
 			},
 		},
 		{
@@ -352,7 +562,7 @@ func TestVerifyIssues(t *testing.T) {
 			issueToVerify: "OCPBUGS-123",
 			tagName:       "4.10",
 			expected: expectedResult{
-				errors:  []error{errors.New("unable to get labels for github pull openshift/vmware-vsphere-csi-driver-operator#105: injected error")},
+				errors:  []error{errors.New("issue OCPBUGS-123: unable to get labels for github pull openshift/vmware-vsphere-csi-driver-operator#105: injected error")},
 				status:  "ON_QA",
 				message: "",
 			},
@@ -372,8 +582,8 @@ func TestVerifyIssues(t *testing.T) {
 			ghCommentMap := make(map[int][]github.IssueComment, 0)
 			upstreamFakeGH := &fakegithub.FakeClient{IssueLabelsExisting: tc.gitHubFakeClientData.issueLabelsExisting, IssueComments: ghCommentMap}
 			gh := &fakeGHClient{GetIssueLabelsError: tc.labelsError, FakeClient: upstreamFakeGH}
-			v := NewVerifier(jc, gh, &plugins.Configuration{})
-			err := v.VerifyIssues([]string{tc.issueToVerify}, tc.tagName)
+			v := NewVerifier(jc, gh, &plugins.Configuration{}, VerifierOptions{ConfirmTransitions: true})
+			_, err := v.VerifyIssues([]string{tc.issueToVerify}, tc.tagName)
 			if len(err) != len(tc.expected.errors) {
 				t.Errorf("number of errors (%d) does not match expected number of errors (%d)", len(err), len(tc.expected.errors))
 			}
@@ -410,6 +620,811 @@ func TestVerifyIssues(t *testing.T) {
 	}
 }
 
+// narrowJiraClient implements only the jira methods used by the Verifier
+type narrowJiraClient struct {
+	issue *jira.Issue
+	// issues overrides issue for the IDs it contains
+	issues   map[string]*jira.Issue
+	issueErr error
+	// issueLookups counts the calls made to GetIssue
+	issueLookups int
+	remoteLinks  []jira.RemoteLink
+	// issueRemoteLinks overrides remoteLinks for the IDs it contains
+	issueRemoteLinks map[string][]jira.RemoteLink
+	remoteLinksErr   error
+	// remoteLinkLookups counts the calls made to GetRemoteLinks
+	remoteLinkLookups int
+	updatedStatus     string
+	updates           int
+	// ignoreUpdates makes UpdateStatus succeed without changing the issue, like a transition dropped by a workflow rule
+	ignoreUpdates bool
+	// delayedReads makes an update visible only after that many more GetIssue calls, like an eventually consistent read
+	delayedReads  int
+	pendingStatus string
+	commentErr    error
+}
+
+func (f *narrowJiraClient) GetIssue(id string) (*jira.Issue, error) {
+	f.issueLookups++
+	if f.issueErr != nil {
+		return nil, f.issueErr
+	}
+	if issue, ok := f.issues[id]; ok {
+		return issue, nil
+	}
+	if f.pendingStatus != "" {
+		if f.delayedReads == 0 {
+			f.issue.Fields.Status.Name = f.pendingStatus
+			f.pendingStatus = ""
+		} else {
+			f.delayedReads--
+		}
+	}
+	return f.issue, nil
+}
+
+func (f *narrowJiraClient) GetRemoteLinks(id string) ([]jira.RemoteLink, error) {
+	f.remoteLinkLookups++
+	if links, ok := f.issueRemoteLinks[id]; ok {
+		return links, f.remoteLinksErr
+	}
+	return f.remoteLinks, f.remoteLinksErr
+}
+
+func (f *narrowJiraClient) AddComment(issueID string, comment *jira.Comment) (*jira.Comment, error) {
+	if f.commentErr != nil {
+		return nil, f.commentErr
+	}
+	comment.Author = jira.User{Name: "openshift-crt-jira-release-controller"}
+	f.issue.Fields.Comments.Comments = append(f.issue.Fields.Comments.Comments, comment)
+	return comment, nil
+}
+
+func (f *narrowJiraClient) UpdateStatus(issueID, statusName string) error {
+	f.updatedStatus = statusName
+	f.updates++
+	switch {
+	case f.ignoreUpdates:
+	case f.delayedReads > 0:
+		f.pendingStatus = statusName
+	default:
+		f.issue.Fields.Status.Name = statusName
+	}
+	return nil
+}
+
+// narrowGHClient implements only the github methods used by the Verifier
+type narrowGHClient struct {
+	labels []github.Label
+	// prLabels overrides labels for the PRs it contains, keyed by org/repo#number
+	prLabels  map[string][]github.Label
+	labelsErr error
+	comments  []string
+	// labelLookups and commentLookups count the calls made to GetIssueLabels and ListIssueComments
+	labelLookups   int
+	commentLookups int
+}
+
+func (f *narrowGHClient) GetIssueLabels(org, repo string, number int) ([]github.Label, error) {
+	f.labelLookups++
+	if f.labelsErr != nil {
+		return nil, f.labelsErr
+	}
+	if labels, ok := f.prLabels[fmt.Sprintf("%s/%s#%d", org, repo, number)]; ok {
+		return labels, nil
+	}
+	return f.labels, nil
+}
+
+func (f *narrowGHClient) CreateComment(org, repo string, number int, comment string) error {
+	f.comments = append(f.comments, comment)
+	return nil
+}
+
+func (f *narrowGHClient) ListIssueComments(org, repo string, number int) ([]github.IssueComment, error) {
+	f.commentLookups++
+	var comments []github.IssueComment
+	for _, body := range f.comments {
+		comments = append(comments, github.IssueComment{Body: body})
+	}
+	return comments, nil
+}
+
+// testPRs are the PRs linked to the issue by remoteLinksJSON
+var testPRs = []pr{{org: "openshift", repo: "vmware-vsphere-csi-driver-operator", prNum: 105}}
+
+// qeApproved are the labels of a PR approved by the QA contact
+var qeApproved = []github.Label{{Name: "qe-approved"}}
+
+// newTestVerifier returns a Verifier backed by narrow fake clients. The fake jira client returns the issue in
+// issueJSON linked to the PR in remoteLinksJSON, and the fake github client returns labels for every PR.
+func newTestVerifier(t *testing.T, issueJSON string, labels []github.Label, options VerifierOptions) (*Verifier, *narrowJiraClient, *narrowGHClient) {
+	t.Helper()
+	var issue jira.Issue
+	if err := readJSONIntoObject(issueJSON, &issue); err != nil {
+		t.Fatalf(err.Error())
+	}
+	var remoteLinks []jira.RemoteLink
+	if err := json.Unmarshal([]byte(remoteLinksJSON), &remoteLinks); err != nil {
+		t.Fatalf("Failed to unmarshall remoteLinksJSON")
+	}
+	jc := &narrowJiraClient{issue: &issue, remoteLinks: remoteLinks}
+	gh := &narrowGHClient{labels: labels}
+	return NewVerifier(jc, gh, &plugins.Configuration{}, options), jc, gh
+}
+
+func TestVerifyIssuesWithNarrowClients(t *testing.T) {
+	v, jc, gh := newTestVerifier(t, onQAIssueJSON, qeApproved, VerifierOptions{ConfirmTransitions: true})
+	if _, errs := v.VerifyIssues([]string{"OCPBUGS-123"}, "4.10"); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if jc.updatedStatus != "VERIFIED" {
+		t.Errorf("expected issue to be moved to VERIFIED, got %q", jc.updatedStatus)
+	}
+	if len(gh.comments) != 1 {
+		t.Errorf("expected 1 comment on the PR, got %d", len(gh.comments))
+	}
+}
+
+func TestVerifyIssuesConcurrently(t *testing.T) {
+	v, jc, gh := newTestVerifier(t, onQAIssueJSON, qeApproved, VerifierOptions{ConfirmTransitions: true})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, errs := v.VerifyIssues([]string{"OCPBUGS-123"}, "4.10"); len(errs) != 0 {
+				t.Errorf("unexpected errors: %v", errs)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if jc.updates != 1 {
+		t.Errorf("expected the issue to be transitioned once, got %d", jc.updates)
+	}
+	if len(jc.issue.Fields.Comments.Comments) != 1 {
+		t.Errorf("expected the issue to be commented once, got %d", len(jc.issue.Fields.Comments.Comments))
+	}
+	if len(gh.comments) != 1 {
+		t.Errorf("expected the PR to be commented once, got %d", len(gh.comments))
+	}
+	if len(v.issueLocks.locks) != 0 {
+		t.Errorf("expected all issue locks to be released, got %d", len(v.issueLocks.locks))
+	}
+}
+
+func TestVerifyIssueDecision(t *testing.T) {
+	testCases := []struct {
+		name       string
+		issueJSON  string
+		labels     []github.Label
+		tagName    string
+		issueErr   error
+		labelsErr  error
+		commentErr error
+		expected   string
+		outcome    string
+		// transitioned is set if the issue is expected to be moved to VERIFIED
+		transitioned bool
+		expectedErrs int
+	}{
+		{
+			name:         "Approved",
+			issueJSON:    onQAIssueJSON,
+			labels:       qeApproved,
+			tagName:      "4.10",
+			expected:     ReasonVerified,
+			outcome:      OutcomeVerified,
+			transitioned: true,
+		},
+		{
+			name:      "Not approved",
+			issueJSON: onQAIssueJSON,
+			tagName:   "4.10",
+			expected:  ReasonNotApproved,
+			outcome:   OutcomeNotApproved,
+		},
+		{
+			name:      "Already verified",
+			issueJSON: verifiedIssueJSON,
+			tagName:   "4.10",
+			expected:  ReasonAlreadyVerified,
+			outcome:   OutcomeAlreadyVerified,
+		},
+		{
+			name:      "Wrong status",
+			issueJSON: inProgressIssueJSON,
+			tagName:   "4.10",
+			expected:  ReasonNotOnQA,
+			outcome:   OutcomeSkipped,
+		},
+		{
+			name:      "Missing status",
+			issueJSON: noStatusIssueJSON,
+			labels:    qeApproved,
+			tagName:   "4.10",
+			expected:  ReasonNoStatus,
+			outcome:   OutcomeSkipped,
+		},
+		{
+			name:      "Empty status",
+			issueJSON: emptyStatusIssueJSON,
+			labels:    qeApproved,
+			tagName:   "4.10",
+			expected:  ReasonNoStatus,
+			outcome:   OutcomeSkipped,
+		},
+		{
+			name:      "Different release",
+			issueJSON: onQAIssueJSON,
+			tagName:   "4.12",
+			expected:  ReasonOtherRelease,
+			outcome:   OutcomeSkipped,
+		},
+		{
+			name:      "Permissions error",
+			issueJSON: onQAIssueJSON,
+			tagName:   "4.10",
+			issueErr:  &prowjira.JiraError{StatusCode: 403, OriginalError: errors.New("forbidden")},
+			expected:  ReasonIssuePermissions,
+			outcome:   OutcomeSkipped,
+		},
+		{
+			name:         "Jira error",
+			issueJSON:    onQAIssueJSON,
+			tagName:      "4.10",
+			issueErr:     errors.New("injected error"),
+			expected:     ReasonGetIssueFailed,
+			outcome:      OutcomeFailed,
+			expectedErrs: 1,
+		},
+		{
+			name:         "Label lookup error",
+			issueJSON:    onQAIssueJSON,
+			tagName:      "4.10",
+			labelsErr:    errors.New("injected error"),
+			expected:     ReasonLabelLookupFailed,
+			outcome:      OutcomeFailed,
+			expectedErrs: 1,
+		},
+		{
+			// the issue is still moved to VERIFIED, but syncJira retries the release until the comment is made
+			name:         "Comment error",
+			issueJSON:    onQAIssueJSON,
+			labels:       qeApproved,
+			tagName:      "4.10",
+			commentErr:   errors.New("injected error"),
+			expected:     ReasonCommentFailed,
+			outcome:      OutcomeFailed,
+			transitioned: true,
+			expectedErrs: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v, jc, gh := newTestVerifier(t, tc.issueJSON, tc.labels, VerifierOptions{ConfirmTransitions: true})
+			jc.issueErr = tc.issueErr
+			jc.commentErr = tc.commentErr
+			gh.labelsErr = tc.labelsErr
+			var errs []error
+			result := v.verifyIssue("OCPBUGS-123", testPRs, newPRCache(), tc.tagName, tc.tagName, &jiraFailures{}, &errs)
+			if len(errs) != tc.expectedErrs {
+				t.Fatalf("expected %d errors, got: %v", tc.expectedErrs, errs)
+			}
+			if result.Reason != tc.expected {
+				t.Errorf("expected reason %q, got %q", tc.expected, result.Reason)
+			}
+			if result.Outcome != tc.outcome {
+				t.Errorf("expected outcome %q, got %q", tc.outcome, result.Outcome)
+			}
+			if transitioned := jc.updates != 0; transitioned != tc.transitioned {
+				t.Errorf("expected transitioned to be %t, got %d status updates", tc.transitioned, jc.updates)
+			}
+		})
+	}
+}
+
+func TestVerifyIssueDenylist(t *testing.T) {
+	v, jc, gh := newTestVerifier(t, onQAIssueJSON, qeApproved, VerifierOptions{ConfirmTransitions: true, Denylist: []string{"OCPBUGS-123"}})
+	var errs []error
+	result := v.verifyIssue("OCPBUGS-123", testPRs, newPRCache(), "4.10", "4.10", &jiraFailures{}, &errs)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if result.Outcome != OutcomeSkipped || result.Reason != ReasonDenylisted {
+		t.Errorf("expected the approved issue to be skipped as denylisted, got %q (%s)", result.Outcome, result.Reason)
+	}
+	if jc.updates != 0 || len(jc.issue.Fields.Comments.Comments) != 0 || len(gh.comments) != 0 {
+		t.Errorf("expected no writes, got %d status updates, %d issue comments and %d PR comments", jc.updates, len(jc.issue.Fields.Comments.Comments), len(gh.comments))
+	}
+	if jc.issueLookups != 0 || gh.labelLookups != 0 {
+		t.Errorf("expected no lookups, got %d issue and %d label lookups", jc.issueLookups, gh.labelLookups)
+	}
+
+	// other issues are still verified
+	result = v.verifyIssue("OCPBUGS-124", testPRs, newPRCache(), "4.10", "4.10", &jiraFailures{}, &errs)
+	if result.Outcome != OutcomeVerified {
+		t.Errorf("expected an issue missing from the denylist to be verified, got %q (%s)", result.Outcome, result.Reason)
+	}
+}
+
+func TestVerifyIssueEnabledRepos(t *testing.T) {
+	testCases := []struct {
+		name         string
+		enabledRepos []string
+		labels       []github.Label
+		outcome      string
+		transitioned bool
+	}{
+		{
+			name:         "All repositories enabled",
+			labels:       qeApproved,
+			outcome:      OutcomeVerified,
+			transitioned: true,
+		},
+		{
+			name:         "Repository enabled",
+			enabledRepos: []string{"openshift/origin", "openshift/vmware-vsphere-csi-driver-operator"},
+			labels:       qeApproved,
+			outcome:      OutcomeVerified,
+			transitioned: true,
+		},
+		{
+			name:         "Repository not enabled",
+			enabledRepos: []string{"openshift/origin"},
+			labels:       qeApproved,
+			outcome:      OutcomeReportOnly,
+		},
+		{
+			name:         "Repository not enabled and PR not approved",
+			enabledRepos: []string{"openshift/origin"},
+			outcome:      OutcomeNotApproved,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v, jc, gh := newTestVerifier(t, onQAIssueJSON, tc.labels, VerifierOptions{ConfirmTransitions: true, EnabledRepos: tc.enabledRepos})
+			var errs []error
+			result := v.verifyIssue("OCPBUGS-123", testPRs, newPRCache(), "4.10", "4.10", &jiraFailures{}, &errs)
+			if len(errs) != 0 {
+				t.Fatalf("unexpected errors: %v", errs)
+			}
+			if result.Outcome != tc.outcome {
+				t.Errorf("expected outcome %q, got %q (%s)", tc.outcome, result.Outcome, result.Reason)
+			}
+			if transitioned := jc.updates != 0; transitioned != tc.transitioned {
+				t.Errorf("expected transitioned to be %t, got %d status updates", tc.transitioned, jc.updates)
+			}
+			// the PRs are evaluated and commented on either way
+			if gh.labelLookups != 1 || len(gh.comments) != 1 {
+				t.Errorf("expected the PR to be looked up and commented on once, got %d lookups and %d comments", gh.labelLookups, len(gh.comments))
+			}
+			if tc.outcome == OutcomeReportOnly && len(jc.issue.Fields.Comments.Comments) != 0 {
+				t.Errorf("expected no issue comment announcing the transition, got %q", jc.issue.Fields.Comments.Comments[0].Body)
+			}
+		})
+	}
+}
+
+func TestVerifyIssueConfirmTransition(t *testing.T) {
+	testCases := []struct {
+		name          string
+		confirm       bool
+		ignoreUpdates bool
+		delayedReads  int
+		expected      string
+		expectedErr   string
+	}{
+		{
+			name:     "Confirmed",
+			confirm:  true,
+			expected: ReasonVerified,
+		},
+		{
+			name:         "Confirmed after stale reads",
+			confirm:      true,
+			delayedReads: 2,
+			expected:     ReasonVerified,
+		},
+		{
+			name:          "Never confirmed",
+			confirm:       true,
+			ignoreUpdates: true,
+			expected:      ReasonTransitionUnconfirmed,
+			expectedErr:   `failed to update status for issue OCPBUGS-123: status is "ON_QA" after the update to VERIFIED was accepted`,
+		},
+		{
+			name:          "Confirmation disabled",
+			ignoreUpdates: true,
+			expected:      ReasonVerified,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v, jc, _ := newTestVerifier(t, onQAIssueJSON, qeApproved, VerifierOptions{ConfirmTransitions: tc.confirm})
+			jc.ignoreUpdates = tc.ignoreUpdates
+			jc.delayedReads = tc.delayedReads
+			v.confirmInterval = time.Millisecond
+			v.confirmTimeout = 50 * time.Millisecond
+			var errs []error
+			result := v.verifyIssue("OCPBUGS-123", testPRs, newPRCache(), "4.10", "4.10", &jiraFailures{}, &errs)
+			if result.Reason != tc.expected {
+				t.Errorf("expected reason %q, got %q", tc.expected, result.Reason)
+			}
+			if tc.expectedErr == "" {
+				if len(errs) != 0 {
+					t.Errorf("unexpected errors: %v", errs)
+				}
+			} else if len(errs) != 1 || errs[0].Error() != tc.expectedErr {
+				t.Errorf("expected error %q, got %v", tc.expectedErr, errs)
+			}
+		})
+	}
+}
+
+func TestVerifyIssueDryRun(t *testing.T) {
+	for _, labels := range [][]github.Label{qeApproved, nil} {
+		v, jc, gh := newTestVerifier(t, onQAIssueJSON, labels, VerifierOptions{DryRun: true, ConfirmTransitions: true})
+		if _, errs := v.VerifyIssues([]string{"OCPBUGS-123"}, "4.10"); len(errs) != 0 {
+			t.Fatalf("unexpected errors: %v", errs)
+		}
+		if jc.updates != 0 {
+			t.Errorf("expected no status updates in dry run, got %d", jc.updates)
+		}
+		if comments := jc.issue.Fields.Comments; comments != nil && len(comments.Comments) != 0 {
+			t.Errorf("expected no issue comments in dry run, got %d", len(comments.Comments))
+		}
+		if len(gh.comments) != 0 {
+			t.Errorf("expected no PR comments in dry run, got %d", len(gh.comments))
+		}
+		// the existing comments are still read, so that the logged comments are only those that would be made
+		if gh.commentLookups != 1 {
+			t.Errorf("expected the PR comments to be looked up once in dry run, got %d", gh.commentLookups)
+		}
+		if jc.issueLookups != 2 {
+			t.Errorf("expected the issue and its comments to be looked up in dry run, got %d lookups", jc.issueLookups)
+		}
+	}
+
+	v, _, _ := newTestVerifier(t, onQAIssueJSON, qeApproved, VerifierOptions{DryRun: true, ConfirmTransitions: true})
+	var errs []error
+	result := v.verifyIssue("OCPBUGS-123", testPRs, newPRCache(), "4.10", "4.10", &jiraFailures{}, &errs)
+	if result.Reason != ReasonApprovedDryRun {
+		t.Errorf("expected reason %q, got %q", ReasonApprovedDryRun, result.Reason)
+	}
+	if result.Outcome != OutcomeApprovedDryRun {
+		t.Errorf("expected outcome %q, got %q", OutcomeApprovedDryRun, result.Outcome)
+	}
+}
+
+func TestVerifyIssueMultiplePRs(t *testing.T) {
+	extPRs := []pr{
+		{org: "openshift", repo: "origin", prNum: 1},
+		{org: "openshift", repo: "installer", prNum: 2},
+		{org: "openshift", repo: "console", prNum: 3},
+	}
+	testCases := []struct {
+		name       string
+		unapproved []string
+		expected   string
+	}{
+		{
+			name:     "All PRs approved",
+			expected: ReasonVerified,
+		},
+		{
+			name:       "First PR not approved",
+			unapproved: []string{"openshift/origin#1"},
+			expected:   ReasonNotApproved,
+		},
+		{
+			name:       "Middle PR not approved",
+			unapproved: []string{"openshift/installer#2"},
+			expected:   ReasonNotApproved,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v, jc, gh := newTestVerifier(t, onQAIssueJSON, qeApproved, VerifierOptions{ConfirmTransitions: true})
+			gh.prLabels = map[string][]github.Label{}
+			for _, unapproved := range tc.unapproved {
+				gh.prLabels[unapproved] = nil
+			}
+			var errs []error
+			result := v.verifyIssue("OCPBUGS-123", extPRs, newPRCache(), "4.10", "4.10", &jiraFailures{}, &errs)
+			if len(errs) != 0 {
+				t.Fatalf("unexpected errors: %v", errs)
+			}
+			if result.Reason != tc.expected {
+				t.Errorf("expected reason %q, got %q", tc.expected, result.Reason)
+			}
+			if len(tc.unapproved) == 0 {
+				return
+			}
+			if jc.updates != 0 {
+				t.Errorf("expected no transition, got %d", jc.updates)
+			}
+			comment := jc.issue.Fields.Comments.Comments[0].Body
+			for _, extPR := range extPRs {
+				id := fmt.Sprintf("%s/%s#%d", extPR.org, extPR.repo, extPR.prNum)
+				listed := strings.Contains(comment, fmt.Sprintf("- PR %s (", id))
+				if expected := sets.NewString(tc.unapproved...).Has(id); listed != expected {
+					t.Errorf("expected PR %s to be listed as not approved: %t, comment: %q", id, expected, comment)
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyIssuesCachesPRLookups(t *testing.T) {
+	testCases := []struct {
+		name           string
+		labelsErr      error
+		expectedErrs   []string
+		commentLookups int
+		outcome        string
+		reason         string
+	}{
+		{
+			name:           "Shared PR",
+			commentLookups: 1,
+			outcome:        OutcomeNotApproved,
+			reason:         ReasonNotApproved,
+		},
+		{
+			name:      "Shared PR with failing label lookup",
+			labelsErr: errors.New("injected error"),
+			expectedErrs: []string{
+				"issue OCPBUGS-123: unable to get labels for github pull openshift/vmware-vsphere-csi-driver-operator#105: injected error",
+				"issue OCPBUGS-124: unable to get labels for github pull openshift/vmware-vsphere-csi-driver-operator#105: injected error",
+			},
+			outcome: OutcomeFailed,
+			reason:  ReasonLabelLookupFailed,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			outcomes := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "outcomes"}, []string{"outcome", "reason"})
+			// both issues are linked to the same PR, which is not approved, so neither is transitioned
+			v, jc, gh := newTestVerifier(t, onQAIssueJSON, nil, VerifierOptions{ConfirmTransitions: true, OutcomeMetrics: outcomes})
+			var otherIssue jira.Issue
+			if err := readJSONIntoObject(onQAIssueJSON, &otherIssue); err != nil {
+				t.Fatalf(err.Error())
+			}
+			otherIssue.Key = "OCPBUGS-124"
+			jc.issues = map[string]*jira.Issue{"OCPBUGS-124": &otherIssue}
+			gh.labelsErr = tc.labelsErr
+			_, errs := v.VerifyIssues([]string{"OCPBUGS-123", "OCPBUGS-124"}, "4.10")
+			actualErrs := sets.NewString()
+			for _, err := range errs {
+				actualErrs.Insert(err.Error())
+			}
+			if expected := sets.NewString(tc.expectedErrs...); len(errs) != len(tc.expectedErrs) || !actualErrs.Equal(expected) {
+				t.Errorf("expected errors %v, got %v", expected.List(), errs)
+			}
+			if actual := testutil.ToFloat64(outcomes.WithLabelValues(tc.outcome, tc.reason)); actual != 2 {
+				t.Errorf("expected both issues to have outcome %q (%s), got %v", tc.outcome, tc.reason, actual)
+			}
+			if gh.labelLookups != 1 {
+				t.Errorf("expected the PR labels to be looked up once, got %d", gh.labelLookups)
+			}
+			if gh.commentLookups != tc.commentLookups {
+				t.Errorf("expected the PR comments to be looked up %d times, got %d", tc.commentLookups, gh.commentLookups)
+			}
+			// the cache must not outlive a single VerifyIssues call
+			v.VerifyIssues([]string{"OCPBUGS-123"}, "4.10")
+			if gh.labelLookups != 2 {
+				t.Errorf("expected the PR labels to be looked up again by the next call, got %d lookups", gh.labelLookups)
+			}
+		})
+	}
+}
+
+func TestVerifyIssuesOutcomeMetrics(t *testing.T) {
+	outcomes := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "outcomes"}, []string{"outcome", "reason"})
+	v, jc, _ := newTestVerifier(t, onQAIssueJSON, qeApproved, VerifierOptions{ConfirmTransitions: true, OutcomeMetrics: outcomes})
+	for i := 0; i < 2; i++ {
+		if _, errs := v.VerifyIssues([]string{"OCPBUGS-123"}, "4.10"); len(errs) != 0 {
+			t.Fatalf("unexpected errors: %v", errs)
+		}
+	}
+	if actual := testutil.ToFloat64(outcomes.WithLabelValues(OutcomeVerified, ReasonVerified)); actual != 1 {
+		t.Errorf("expected 1 verified issue, got %v", actual)
+	}
+	if actual := testutil.ToFloat64(outcomes.WithLabelValues(OutcomeAlreadyVerified, ReasonAlreadyVerified)); actual != 1 {
+		t.Errorf("expected 1 already verified issue, got %v", actual)
+	}
+
+	// issues dropped before their status is checked are counted too
+	jc.remoteLinks = nil
+	if _, errs := v.VerifyIssues([]string{"OCPBUGS-123"}, "4.10"); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if actual := testutil.ToFloat64(outcomes.WithLabelValues(OutcomeSkipped, ReasonNoRemoteLinks)); actual != 1 {
+		t.Errorf("expected 1 skipped issue, got %v", actual)
+	}
+	jc.remoteLinksErr = errors.New("injected error")
+	if _, errs := v.VerifyIssues([]string{"OCPBUGS-123"}, "4.10"); len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %v", errs)
+	}
+	if actual := testutil.ToFloat64(outcomes.WithLabelValues(OutcomeFailed, ReasonRemoteLinksFailed)); actual != 1 {
+		t.Errorf("expected 1 failed issue, got %v", actual)
+	}
+}
+
+func TestVerifyIssuesResults(t *testing.T) {
+	v, jc, _ := newTestVerifier(t, onQAIssueJSON, qeApproved, VerifierOptions{ConfirmTransitions: true})
+	var verifiedIssue jira.Issue
+	if err := readJSONIntoObject(verifiedIssueJSON, &verifiedIssue); err != nil {
+		t.Fatalf(err.Error())
+	}
+	verifiedIssue.Key = "OCPBUGS-124"
+	jc.issues = map[string]*jira.Issue{"OCPBUGS-124": &verifiedIssue}
+	jc.issueRemoteLinks = map[string][]jira.RemoteLink{"OCPBUGS-125": nil}
+
+	results, errs := v.VerifyIssues([]string{"OCPBUGS-125", "OCPBUGS-123", "OCPBUGS-124", "OCPBUGS-123"}, "4.10")
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	prURL := "https://github.com/openshift/vmware-vsphere-csi-driver-operator/pull/105"
+	expected := []Result{
+		{Issue: "OCPBUGS-125", Outcome: OutcomeSkipped, Reason: ReasonNoRemoteLinks},
+		{Issue: "OCPBUGS-123", PRs: []string{prURL}, Status: "ON_QA", Outcome: OutcomeVerified, Reason: ReasonVerified},
+		{Issue: "OCPBUGS-124", PRs: []string{prURL}, Status: "Verified", Outcome: OutcomeAlreadyVerified, Reason: ReasonAlreadyVerified},
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("expected results %+v, got %+v", expected, results)
+	}
+
+	data, err := json.Marshal(results[:2])
+	if err != nil {
+		t.Fatalf("failed to marshal the results: %v", err)
+	}
+	if actual, expected := string(data), `[{"issue":"OCPBUGS-125","outcome":"skipped","reason":"skipped, issue has no remote links"},{"issue":"OCPBUGS-123","prs":["`+prURL+`"],"status":"ON_QA","outcome":"verified","reason":"approved, moved to VERIFIED"}]`; actual != expected {
+		t.Errorf("expected JSON %s, got %s", expected, actual)
+	}
+}
+
+func TestVerifyIssuesLogsDecisions(t *testing.T) {
+	// klog only writes to the output set by SetOutput when it is not logging to stderr
+	flags := flag.NewFlagSet("klog", flag.ContinueOnError)
+	klog.InitFlags(flags)
+	if err := flags.Set("logtostderr", "false"); err != nil {
+		t.Fatalf("failed to disable logging to stderr: %v", err)
+	}
+	defer flags.Set("logtostderr", "true")
+	var output bytes.Buffer
+	klog.SetOutput(&output)
+
+	v, jc, _ := newTestVerifier(t, onQAIssueJSON, nil, VerifierOptions{ConfirmTransitions: true})
+	jc.issueRemoteLinks = map[string][]jira.RemoteLink{"OCPBUGS-125": nil}
+	if _, errs := v.VerifyIssues([]string{"OCPBUGS-123", "OCPBUGS-125"}, "4.10"); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	klog.Flush()
+
+	var decisions []string
+	for _, line := range strings.Split(output.String(), "\n") {
+		if i := strings.Index(line, "Jira verification of issue "); i != -1 {
+			decisions = append(decisions, line[i:])
+		}
+	}
+	expected := []string{
+		"Jira verification of issue OCPBUGS-123 for 4.10 (PRs: https://github.com/openshift/vmware-vsphere-csi-driver-operator/pull/105): " + ReasonNotApproved,
+		"Jira verification of issue OCPBUGS-125 for 4.10 (PRs: none): " + ReasonNoRemoteLinks,
+	}
+	if !reflect.DeepEqual(decisions, expected) {
+		t.Errorf("expected decision lines %q, got %q", expected, decisions)
+	}
+}
+
+func TestVerifyIssuesJiraUnavailable(t *testing.T) {
+	testCases := []struct {
+		name              string
+		maxFailures       int
+		remoteLinksErr    error
+		issueErr          error
+		remoteLinkLookups int
+		issueLookups      int
+		reasons           []string
+		abortErr          string
+	}{
+		{
+			name:              "Failing remote link lookups",
+			maxFailures:       2,
+			remoteLinksErr:    errors.New("injected error"),
+			remoteLinkLookups: 2,
+			reasons:           []string{ReasonRemoteLinksFailed, ReasonRemoteLinksFailed, ReasonJiraUnavailable, ReasonJiraUnavailable},
+			abortErr:          "Jira unavailable after 2 consecutive failures, aborting the verification of the remaining 2 issues",
+		},
+		{
+			name:              "Failing issue lookups",
+			maxFailures:       2,
+			issueErr:          errors.New("injected error"),
+			remoteLinkLookups: 4,
+			issueLookups:      2,
+			reasons:           []string{ReasonGetIssueFailed, ReasonGetIssueFailed, ReasonJiraUnavailable, ReasonJiraUnavailable},
+			abortErr:          "Jira unavailable after 2 consecutive failures, aborting the verification of the remaining 2 issues",
+		},
+		{
+			name:              "Permission errors",
+			maxFailures:       2,
+			remoteLinksErr:    &prowjira.JiraError{StatusCode: 403, OriginalError: errors.New("forbidden")},
+			remoteLinkLookups: 4,
+			reasons:           []string{ReasonRemoteLinksPermissions, ReasonRemoteLinksPermissions, ReasonRemoteLinksPermissions, ReasonRemoteLinksPermissions},
+		},
+		{
+			name:              "Disabled",
+			remoteLinksErr:    errors.New("injected error"),
+			remoteLinkLookups: 4,
+			reasons:           []string{ReasonRemoteLinksFailed, ReasonRemoteLinksFailed, ReasonRemoteLinksFailed, ReasonRemoteLinksFailed},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v, jc, _ := newTestVerifier(t, onQAIssueJSON, qeApproved, VerifierOptions{ConfirmTransitions: true, MaxConsecutiveFailures: tc.maxFailures})
+			jc.remoteLinksErr = tc.remoteLinksErr
+			jc.issueErr = tc.issueErr
+			results, errs := v.VerifyIssues([]string{"OCPBUGS-1", "OCPBUGS-2", "OCPBUGS-3", "OCPBUGS-4"}, "4.10")
+			if jc.remoteLinkLookups != tc.remoteLinkLookups || jc.issueLookups != tc.issueLookups {
+				t.Errorf("expected %d remote link and %d issue lookups, got %d and %d", tc.remoteLinkLookups, tc.issueLookups, jc.remoteLinkLookups, jc.issueLookups)
+			}
+			var reasons []string
+			for _, result := range results {
+				reasons = append(reasons, result.Reason)
+			}
+			if !reflect.DeepEqual(reasons, tc.reasons) {
+				t.Errorf("expected reasons %q, got %q", tc.reasons, reasons)
+			}
+			var failed int
+			for _, reason := range tc.reasons {
+				if reason == ReasonRemoteLinksFailed || reason == ReasonGetIssueFailed {
+					failed++
+				}
+			}
+			if tc.abortErr == "" {
+				if len(errs) != failed {
+					t.Errorf("expected %d errors, got %v", failed, errs)
+				}
+				return
+			}
+			// one error for each lookup that failed, and a single one for the issues left unchecked
+			if len(errs) != failed+1 || errs[len(errs)-1].Error() != tc.abortErr {
+				t.Errorf("expected %d errors ending with %q, got %v", failed+1, tc.abortErr, errs)
+			}
+		})
+	}
+}
+
+func TestVerifyIssueIgnoresEarlierErrors(t *testing.T) {
+	v, jc, _ := newTestVerifier(t, onQAIssueJSON, qeApproved, VerifierOptions{ConfirmTransitions: true})
+	// an error met for an earlier issue of the same VerifyIssues call
+	errs := []error{errors.New("earlier error")}
+	result := v.verifyIssue("OCPBUGS-123", testPRs, newPRCache(), "4.10", "4.10", &jiraFailures{}, &errs)
+	if result.Outcome != OutcomeVerified || result.Reason != ReasonVerified {
+		t.Errorf("expected the issue to be verified, got %q (%s)", result.Outcome, result.Reason)
+	}
+	if comment := jc.issue.Fields.Comments.Comments[0].Body; strings.Contains(comment, "earlier error") {
+		t.Errorf("expected the issue comment not to mention other issues' errors: %q", comment)
+	}
+	if len(errs) != 1 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
+
+func TestPRURLs(t *testing.T) {
+	extPRs := []pr{{org: "openshift", repo: "origin", prNum: 1}, {org: "openshift", repo: "installer", prNum: 2}}
+	expected := []string{"https://github.com/openshift/origin/pull/1", "https://github.com/openshift/installer/pull/2"}
+	if actual := prURLs(extPRs); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+	if actual := prURLs(nil); len(actual) != 0 {
+		t.Errorf("expected no URLs, got %v", actual)
+	}
+}
+
 const onQAIssueJSON = `
 {
   "key": "OCPBUGS-123",
@@ -564,3 +1579,38 @@ const remoteLinksJSON = `
   }
 ]
 `
+
+const noStatusIssueJSON = `
+{
+  "key": "OCPBUGS-123",
+  "fields": {
+    "customfield_12319940": [
+      {
+        "name": "4.10.z"
+      }
+    ],
+    "comment": {
+      "comments": []
+    }
+  }
+}
+`
+
+const emptyStatusIssueJSON = `
+{
+  "key": "OCPBUGS-123",
+  "fields": {
+    "status": {
+      "name": ""
+    },
+    "customfield_12319940": [
+      {
+        "name": "4.10.z"
+      }
+    ],
+    "comment": {
+      "comments": []
+    }
+  }
+}
+`